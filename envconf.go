@@ -9,7 +9,8 @@
 // or Base64 which will directly translate the env var string to bytes
 //
 // Standard conversion from string to int, bool etc work, as well as custom
-// types which satisfy `SetterFromEnv` (on a pointer, like JSON)
+// types which satisfy `SetterFromEnv` (on a pointer, like JSON), the stdlib
+// `encoding.TextUnmarshaler` and `flag.Value` interfaces, and `time.Duration`
 //
 // Combining translators and custom types is perfectly fine. The string
 // translations will happen first, then the output will be passed into
@@ -17,15 +18,18 @@
 package envconf
 
 import (
+	"encoding"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Translator is responsible for taking a string and converting it to the
@@ -74,15 +78,102 @@ func (tf TranslatorFunc) Translate(in string) (string, error) {
 	return tf(in)
 }
 
+// Source looks up the value for an env var name. Parse consults a Parser's
+// Sources in order and uses the first one that reports ok==true.
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// SourceFunc is an adaptor to allow the use of ordinary functions as Sources
+type SourceFunc func(key string) (string, bool)
+
+// Lookup satisfies the Source interface
+func (f SourceFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// EnvSource is a Source backed by the process environment. It is the
+// implicit Source used when a Parser has none configured via WithSources.
+type EnvSource struct{}
+
+// Lookup satisfies the Source interface
+func (EnvSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// MapSource is a Source backed by an in-memory map, useful for tests and for
+// layering explicit overrides ahead of the environment or a dotenv file.
+type MapSource map[string]string
+
+// Lookup satisfies the Source interface
+func (m MapSource) Lookup(key string) (string, bool) {
+	val, ok := m[key]
+	return val, ok
+}
+
+// DotEnvSource reads KEY=value pairs from a dotenv-style file at path and
+// returns them as a MapSource. Blank lines and lines beginning with # (after
+// leading whitespace) are ignored, a leading `export ` is stripped from the
+// key, and values may be wrapped in single or double quotes.
+func DotEnvSource(path string) (Source, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := MapSource{}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if len(value) >= 2 {
+			quote := value[0]
+			if (quote == '"' || quote == '\'') && value[len(value)-1] == quote {
+				value = value[1 : len(value)-1]
+			}
+		}
+
+		out[key] = value
+	}
+	return out, nil
+}
+
+// ParserFunc converts a string into the value to be set on the field. The
+// returned value must be assignable to the field it was parsed for, e.g. a
+// parser registered for *url.URL should return a *url.URL.
+type ParserFunc func(string) (interface{}, error)
+
+// ValidatorFunc checks a field's final value against arg, the text following
+// `=` in the `validate` tag (empty for argument-less rules like `required`).
+// It returns a descriptive error on failure.
+type ValidatorFunc func(value interface{}, arg string) error
+
 // Parser holds a list of Translator functions
 type Parser struct {
 	Translators map[string]Translator
+	TypeParsers map[reflect.Type]ParserFunc
+	KindParsers map[reflect.Kind]ParserFunc
+	Sources     []Source
+	Validators  map[string]ValidatorFunc
 }
 
 // New returns a new Parser with an empty translator set
 func New() *Parser {
 	return &Parser{
 		Translators: map[string]Translator{},
+		TypeParsers: map[reflect.Type]ParserFunc{},
+		KindParsers: map[reflect.Kind]ParserFunc{},
+		Validators:  defaultValidators(),
 	}
 }
 
@@ -92,22 +183,131 @@ func (p *Parser) RegisterTranslatorFunc(name string, translator func(string) (st
 	p.Translators[name] = TranslatorFunc(translator)
 }
 
+// RegisterParser registers fn to handle fields of the same type as sample,
+// e.g. RegisterParser((*url.URL)(nil), fn) teaches the Parser how to set
+// *url.URL fields without requiring url.URL to implement SetterFromEnv. It is
+// consulted by SetFromString before the built-in type switch, so it can also
+// be used to override the default handling of a built-in type.
+func (p *Parser) RegisterParser(sample interface{}, fn func(string) (interface{}, error)) {
+	if p.TypeParsers == nil {
+		p.TypeParsers = map[reflect.Type]ParserFunc{}
+	}
+	sampleType := reflect.TypeOf(sample)
+	if sampleType.Kind() == reflect.Ptr {
+		sampleType = sampleType.Elem()
+	}
+	p.TypeParsers[sampleType] = ParserFunc(fn)
+}
+
+// RegisterKindParser registers fn for any field whose underlying
+// reflect.Kind matches kind and which has no more specific TypeParsers entry
+// and does not implement SetterFromEnv. It is consulted before the
+// TextUnmarshaler, flag.Value and built-in type handling, so it overrides
+// those for every type of that kind.
+func (p *Parser) RegisterKindParser(kind reflect.Kind, fn func(string) (interface{}, error)) {
+	if p.KindParsers == nil {
+		p.KindParsers = map[reflect.Kind]ParserFunc{}
+	}
+	p.KindParsers[kind] = ParserFunc(fn)
+}
+
+// RegisterValidator registers fn as the handler for a named rule in the
+// `validate` struct tag, e.g. RegisterValidator("oneof", ...) backs
+// `validate:"oneof=dev staging prod"`. It replaces any existing validator
+// with the same name, including the built-ins.
+func (p *Parser) RegisterValidator(name string, fn func(value interface{}, arg string) error) {
+	if p.Validators == nil {
+		p.Validators = map[string]ValidatorFunc{}
+	}
+	p.Validators[name] = ValidatorFunc(fn)
+}
+
+// WithSources sets the ordered list of Sources Parse looks values up from,
+// replacing any previously configured sources. The first Source to return
+// ok==true for a key wins; if none do, the `default`/`required` tags apply as
+// usual. A Parser with no Sources configured falls back to the process
+// environment, so WithSources is only needed to add layering (dotenv files,
+// explicit overrides, tests that don't want to mutate os.Environ) or to
+// change the order.
+func (p *Parser) WithSources(sources ...Source) *Parser {
+	p.Sources = sources
+	return p
+}
+
+// lookup returns the first value reported by p.Sources, falling back to the
+// process environment when no Sources are configured.
+func (p Parser) lookup(key string) (string, bool) {
+	if len(p.Sources) == 0 {
+		return EnvSource{}.Lookup(key)
+	}
+	for _, source := range p.Sources {
+		if val, ok := source.Lookup(key); ok {
+			return val, true
+		}
+	}
+	return "", false
+}
+
 // Parse reads the tags of dest to set any fields which should be parsed from
 // the environment. The `env` tag gives the name of the variable. If the
 // environment variable evaluates to an empty string, the value of `default` is
 // used, or an error is thrown if the `default` tag is omitted.
 // To allow optional parameters, set default to an empty string
+//
+// Struct fields (and pointer-to-struct fields) carrying a `prefix` tag are
+// descended into rather than being unmarshalled as JSON: each inner field's
+// `env` name is looked up with the outer prefix prepended, to arbitrary
+// nesting depth. A struct field with a non-empty `env` tag and no `prefix`
+// tag keeps the original JSON-string behaviour.
+//
+// Once every field is populated, a `validate` tag on any field (nested or
+// not, including struct fields themselves) is checked, e.g. `validate:"min=1,max=65535"`. Unlike the `required`
+// tag, validation failures are collected across every field rather than
+// stopping at the first one; if any fail, Parse returns a *ValidationError.
 func (p Parser) Parse(dest interface{}) error {
-
-	rt := reflect.TypeOf(dest).Elem()
 	rv := reflect.ValueOf(dest).Elem()
+	if err := p.parseStruct(rv, ""); err != nil {
+		return err
+	}
+	if errs := p.validateStruct(rv, ""); len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func (p Parser) parseStruct(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
 	for i := 0; i < rv.NumField(); i++ {
 		tag := rt.Field(i).Tag
 		envName := tag.Get("env")
+		fieldPrefix, hasPrefix := tag.Lookup("prefix")
+
+		fieldVal := rv.Field(i)
+		actualType := fieldVal.Kind()
+
+		if hasPrefix && actualType == reflect.Struct {
+			if err := p.parseStruct(fieldVal, prefix+fieldPrefix); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if hasPrefix && actualType == reflect.Pointer && fieldVal.Type().Elem().Kind() == reflect.Struct {
+			if fieldVal.IsNil() {
+				fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+			}
+			if err := p.parseStruct(fieldVal.Elem(), prefix+fieldPrefix); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if envName == "" {
 			continue
 		}
-		envVal := os.Getenv(envName)
+		envName = prefix + envName
+
+		envVal, _ := p.lookup(envName)
 		if envVal == "" {
 			if defaultValue, ok := tag.Lookup("default"); ok {
 				envVal = defaultValue
@@ -118,25 +318,32 @@ func (p Parser) Parse(dest interface{}) error {
 			}
 		}
 
-		envVal, err := p.Translate(envVal)
-		if err != nil {
-			return fmt.Errorf("In field %s: %s", envName, err)
-		}
-
-		fieldVal := rv.Field(i)
-
 		fieldInterface := fieldVal.Addr().Interface()
 
-		actualType := fieldVal.Kind()
 		if actualType == reflect.Pointer {
 			elemType := fieldVal.Type().Elem()
 			newVal := reflect.New(elemType)
 			fieldVal.Set(newVal)
 			fieldVal = newVal
 			actualType = fieldVal.Elem().Kind()
+			fieldInterface = fieldVal.Interface()
+		}
+
+		// Slices and arrays translate their elements individually (so a
+		// leading `!name:` only applies to the element it prefixes),
+		// everything else is translated as a whole string before being
+		// dispatched.
+		targetType := reflect.TypeOf(fieldInterface).Elem()
+		isSlice := (targetType.Kind() == reflect.Slice || targetType.Kind() == reflect.Array) && targetType.Elem().Kind() != reflect.Uint8 && !p.hasCustomHandler(fieldInterface)
+		if !isSlice {
+			var err error
+			envVal, err = p.Translate(envVal)
+			if err != nil {
+				return fmt.Errorf("In field %s: %s", envName, err)
+			}
 		}
 
-		if actualType == reflect.Struct {
+		if actualType == reflect.Struct && !p.hasCustomHandler(fieldInterface) {
 			if !strings.HasPrefix(envVal, "{") {
 				return fmt.Errorf("In field %s: struct fields should be set using JSON strings", envName)
 			}
@@ -147,7 +354,9 @@ func (p Parser) Parse(dest interface{}) error {
 			continue
 		}
 
-		if err := SetFromString(fieldInterface, envVal); err != nil {
+		separators := separatorChain(tag.Get("separator"))
+
+		if err := p.setFromString(fieldInterface, envVal, separators); err != nil {
 			return fmt.Errorf("In field %s: %s", envName, err)
 		}
 
@@ -155,18 +364,194 @@ func (p Parser) Parse(dest interface{}) error {
 	return nil
 }
 
-// SetFromString attempts to translate a string to the given interface. Must be a pointer.
-// Standard Types string, bool, int, int(8-64) float(32, 64) and []string.
-// Custom types must have method FromEnvString(string) error
+// validateStruct walks rv running each exported field's `validate` tag
+// rules, then descends into every nested struct and non-nil pointer-to-struct
+// field (whether or not it carries a `prefix` tag), collecting every failure
+// rather than stopping at the first.
+func (p Parser) validateStruct(rv reflect.Value, fieldPath string) []error {
+	rt := rv.Type()
+	var errs []error
+	for i := 0; i < rv.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		fieldVal := rv.Field(i)
+		name := fieldPath + field.Name
+
+		if rules, ok := field.Tag.Lookup("validate"); ok && rules != "" {
+			for _, rule := range splitTopLevel(rules) {
+				ruleName, arg, _ := strings.Cut(rule, "=")
+				fn, ok := p.Validators[ruleName]
+				if !ok {
+					errs = append(errs, fmt.Errorf("field %s: unknown validator %q", name, ruleName))
+					continue
+				}
+				if err := fn(fieldVal.Interface(), arg); err != nil {
+					errs = append(errs, fmt.Errorf("field %s: %w", name, err))
+				}
+			}
+		}
+
+		switch {
+		case fieldVal.Kind() == reflect.Struct:
+			errs = append(errs, p.validateStruct(fieldVal, name+".")...)
+		case fieldVal.Kind() == reflect.Pointer && !fieldVal.IsNil() && fieldVal.Type().Elem().Kind() == reflect.Struct:
+			errs = append(errs, p.validateStruct(fieldVal.Elem(), name+".")...)
+		}
+	}
+	return errs
+}
+
+// splitTopLevel splits rules on commas, except commas nested inside (), []
+// or {} pairs, so a rule argument like `regex=^[a-z]{2,4}$` survives intact
+// instead of being torn apart at the quantifier's comma.
+func splitTopLevel(rules string) []string {
+	var out []string
+	depth := 0
+	last := 0
+	for i, r := range rules {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				out = append(out, rules[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(out, rules[last:])
+}
+
+// ValidationError aggregates every failure from Parse's `validate` tag pass,
+// so all misconfigurations are reported together instead of one at a time.
+// Individual failures can be extracted with errors.As, since ValidationError
+// implements Unwrap() []error.
+type ValidationError struct {
+	Errors []error
+}
+
+// Error joins every failure's message with "; "
+func (v *ValidationError) Error() string {
+	msgs := make([]string, len(v.Errors))
+	for i, err := range v.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap satisfies errors.Unwrap's multi-error form (Go 1.20+)
+func (v *ValidationError) Unwrap() []error {
+	return v.Errors
+}
+
+// hasCustomHandler reports whether fieldInterface (a pointer to the field)
+// would be handled by SetterFromEnv, encoding.TextUnmarshaler, flag.Value or
+// a registered parser, rather than falling through to the default
+// struct-as-JSON or per-element slice behaviour.
+func (p *Parser) hasCustomHandler(fieldInterface interface{}) bool {
+	if _, ok := fieldInterface.(SetterFromEnv); ok {
+		return true
+	}
+	if _, ok := fieldInterface.(encoding.TextUnmarshaler); ok {
+		return true
+	}
+	if _, ok := fieldInterface.(flag.Value); ok {
+		return true
+	}
+	fieldType := reflect.TypeOf(fieldInterface).Elem()
+	if _, ok := p.TypeParsers[fieldType]; ok {
+		return true
+	}
+	if _, ok := p.KindParsers[fieldType.Kind()]; ok {
+		return true
+	}
+	return false
+}
+
+// SetFromString uses DefaultParser.SetFromString
 func SetFromString(fieldInterface interface{}, stringVal string) error {
+	return DefaultParser.SetFromString(fieldInterface, stringVal)
+}
+
+// SetFromString attempts to translate a string to the given interface. Must
+// be a pointer. Standard Types string, bool, int, int(8-64), uint, uint(8-64),
+// float(32, 64), time.Duration and slices or arrays of any supported type
+// other than byte (comma separated, trimmed of whitespace; a nested slice or
+// array element falls through to the next separator in
+// defaultSeparatorChain). Custom types must have method
+// FromEnvString(string) error, implement encoding.TextUnmarshaler or
+// flag.Value, or have a parser registered with
+// RegisterParser/RegisterKindParser.
+func (p *Parser) SetFromString(fieldInterface interface{}, stringVal string) error {
+	return p.setFromString(fieldInterface, stringVal, defaultSeparatorChain)
+}
+
+// defaultSeparatorChain is the per-depth delimiter fallback used when a
+// slice or array field (or one of its elements, recursively) has no
+// `separator` tag of its own: the outermost level splits on ",", a nested
+// slice/array level splits on "|", and so on. See separatorChain.
+var defaultSeparatorChain = []string{",", "|", ";", ":"}
+
+// separatorChain builds the per-depth separator list for a field's
+// `separator` tag: tagValue (or "," if empty) is used at the outermost
+// level, and deeper levels - reached when the field is itself a slice or
+// array of slices/arrays - fall back to the remaining entries of
+// defaultSeparatorChain.
+func separatorChain(tagValue string) []string {
+	sep := tagValue
+	if sep == "" {
+		sep = defaultSeparatorChain[0]
+	}
+	chain := []string{sep}
+	for _, d := range defaultSeparatorChain {
+		if d != sep {
+			chain = append(chain, d)
+		}
+	}
+	return chain
+}
+
+// setFromString is SetFromString with an explicit per-depth separator
+// chain, used by Parse to honour a field's `separator` tag at the
+// outermost level and defaultSeparatorChain at any nested slice/array
+// level.
+func (p *Parser) setFromString(fieldInterface interface{}, stringVal string, separators []string) error {
 
 	if withSetter, ok := fieldInterface.(SetterFromEnv); ok {
 		return withSetter.FromEnvString(stringVal)
 	}
 
+	fieldType := reflect.TypeOf(fieldInterface).Elem()
+
+	if fn, ok := p.TypeParsers[fieldType]; ok {
+		return setFromParserFunc(fieldInterface, stringVal, fn)
+	}
+
+	if fn, ok := p.KindParsers[fieldType.Kind()]; ok {
+		return setFromParserFunc(fieldInterface, stringVal, fn)
+	}
+
+	if tu, ok := fieldInterface.(encoding.TextUnmarshaler); ok {
+		return tu.UnmarshalText([]byte(stringVal))
+	}
+
+	if fv, ok := fieldInterface.(flag.Value); ok {
+		return fv.Set(stringVal)
+	}
+
 	var err error
 
 	switch field := fieldInterface.(type) {
+	case *time.Duration:
+		*field, err = time.ParseDuration(stringVal)
+		return err
 	case *string:
 		*field = stringVal
 		return nil
@@ -221,23 +606,88 @@ func SetFromString(fieldInterface interface{}, stringVal string) error {
 		field64, err := strconv.ParseFloat(stringVal, 32)
 		*field = float32(field64)
 		return err
+	}
 
-	// TODO: Support an array of anything. Using reflect?
-	case *[]string:
-		vals := strings.Split(stringVal, ",")
-		out := make([]string, 0, len(vals))
-		for _, val := range vals {
-			stripped := strings.TrimSpace(val)
-			if stripped == "" {
-				continue
-			}
-			out = append(out, stripped)
+	rv := reflect.ValueOf(fieldInterface)
+	elemKind := rv.Elem().Kind()
+	if (elemKind == reflect.Slice || elemKind == reflect.Array) && rv.Elem().Type().Elem().Kind() != reflect.Uint8 {
+		return p.setSliceFromString(rv.Elem(), stringVal, separators)
+	}
+
+	return fmt.Errorf("unsupported type %T", fieldInterface)
+}
+
+// setSliceFromString splits stringVal on separators[0] and decodes each
+// element through setFromString (passing separators[1:] so a nested
+// slice/array element splits on the next delimiter in the chain, rather
+// than colliding with the outer one), using the same translators,
+// SetterFromEnv, TextUnmarshaler and registered-parser dispatch as scalar
+// fields. Empty (whitespace-only) elements are skipped, so a trailing
+// separator is harmless. Elements may carry their own `!name:` translator
+// prefix, e.g. `!base64:Zm9v,!base64:YmFy`. sliceVal may be a fixed-size
+// array, in which case it is an error to supply more elements than its
+// length; any elements beyond those supplied are left unchanged.
+func (p *Parser) setSliceFromString(sliceVal reflect.Value, stringVal string, separators []string) error {
+	separator := separators[0]
+	nextSeparators := separators
+	if len(separators) > 1 {
+		nextSeparators = separators[1:]
+	}
+
+	parts := strings.Split(stringVal, separator)
+	elemType := sliceVal.Type().Elem()
+
+	var elems []reflect.Value
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		part, err := p.Translate(part)
+		if err != nil {
+			return err
+		}
+
+		elemVal := reflect.New(elemType)
+		if err := p.setFromString(elemVal.Interface(), part, nextSeparators); err != nil {
+			return err
+		}
+		elems = append(elems, elemVal.Elem())
+	}
+
+	if sliceVal.Kind() == reflect.Array {
+		if len(elems) > sliceVal.Len() {
+			return fmt.Errorf("too many elements (%d) for array of length %d", len(elems), sliceVal.Len())
+		}
+		for i, elem := range elems {
+			sliceVal.Index(i).Set(elem)
 		}
-		*field = out
 		return nil
 	}
 
-	return fmt.Errorf("unsupported type %T", fieldInterface)
+	out := reflect.MakeSlice(sliceVal.Type(), 0, len(elems))
+	out = reflect.Append(out, elems...)
+	sliceVal.Set(out)
+	return nil
+}
+
+// setFromParserFunc runs fn and assigns the result onto fieldInterface, which
+// must be a pointer to the type fn's result is assignable to. fn may also
+// return a pointer to that type, as url.Parse does, in which case the value
+// is dereferenced before assignment.
+func setFromParserFunc(fieldInterface interface{}, stringVal string, fn ParserFunc) error {
+	val, err := fn(stringVal)
+	if err != nil {
+		return err
+	}
+	target := reflect.ValueOf(fieldInterface).Elem()
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Ptr && rv.Type().Elem() == target.Type() {
+		rv = rv.Elem()
+	}
+	target.Set(rv)
+	return nil
 }
 
 var reTranslate = regexp.MustCompile(`^!([a-zA-Z0-9_\-]+):`)
@@ -271,6 +721,9 @@ var DefaultParser = Parser{
 	Translators: map[string]Translator{
 		"base64": TranslatorFunc(Base64Translator),
 	},
+	TypeParsers: map[reflect.Type]ParserFunc{},
+	KindParsers: map[reflect.Kind]ParserFunc{},
+	Validators:  defaultValidators(),
 }
 
 // Parse uses DefaultParser.Parse
@@ -292,3 +745,141 @@ func Base64Translator(in string) (string, error) {
 	}
 	return string(data), nil
 }
+
+// defaultValidators returns the built-in set of `validate` tag rules: min,
+// max, oneof, required, nonempty, regex, url and email.
+func defaultValidators() map[string]ValidatorFunc {
+	return map[string]ValidatorFunc{
+		"min":      validateMin,
+		"max":      validateMax,
+		"oneof":    validateOneOf,
+		"required": validateRequired,
+		"nonempty": validateNonempty,
+		"regex":    validateRegex,
+		"url":      validateURL,
+		"email":    validateEmail,
+	}
+}
+
+// numericOrLen reports the numeric value of value for min/max comparison: the
+// value itself for numeric kinds, or the length for strings, slices, arrays
+// and maps.
+func numericOrLen(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(rv.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// validateMin backs `validate:"min=N"`: N is a lower bound on numeric values,
+// or a minimum length for strings, slices, arrays and maps.
+func validateMin(value interface{}, arg string) error {
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("invalid min argument %q: %s", arg, err)
+	}
+	n, ok := numericOrLen(value)
+	if !ok {
+		return fmt.Errorf("min is not supported for %T", value)
+	}
+	if n < limit {
+		return fmt.Errorf("must be at least %s, got %v", arg, value)
+	}
+	return nil
+}
+
+// validateMax backs `validate:"max=N"`: N is an upper bound on numeric
+// values, or a maximum length for strings, slices, arrays and maps.
+func validateMax(value interface{}, arg string) error {
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("invalid max argument %q: %s", arg, err)
+	}
+	n, ok := numericOrLen(value)
+	if !ok {
+		return fmt.Errorf("max is not supported for %T", value)
+	}
+	if n > limit {
+		return fmt.Errorf("must be at most %s, got %v", arg, value)
+	}
+	return nil
+}
+
+// validateOneOf backs `validate:"oneof=a b c"`: the value, formatted with
+// %v, must equal one of the space separated options.
+func validateOneOf(value interface{}, arg string) error {
+	str := fmt.Sprintf("%v", value)
+	for _, opt := range strings.Fields(arg) {
+		if opt == str {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of [%s], got %q", arg, str)
+}
+
+// validateRequired backs `validate:"required"`: the value must not be its
+// type's zero value. Unlike the load-time `required` tag, this runs after
+// defaults are applied, so a field with both a `default` and
+// `validate:"required"` still fails if the default itself is the zero value.
+func validateRequired(value interface{}, _ string) error {
+	if reflect.ValueOf(value).IsZero() {
+		return fmt.Errorf("must not be the zero value")
+	}
+	return nil
+}
+
+// validateNonempty backs `validate:"nonempty"`: strings, slices, arrays and
+// maps must have at least one element; other types fall back to
+// validateRequired's zero-value check.
+func validateNonempty(value interface{}, arg string) error {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		if rv.Len() == 0 {
+			return fmt.Errorf("must not be empty")
+		}
+		return nil
+	}
+	return validateRequired(value, arg)
+}
+
+// validateRegex backs `validate:"regex=<pattern>"`: the value, formatted
+// with %v, must match pattern.
+func validateRegex(value interface{}, arg string) error {
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return fmt.Errorf("invalid regex %q: %s", arg, err)
+	}
+	if !re.MatchString(fmt.Sprintf("%v", value)) {
+		return fmt.Errorf("must match %s", arg)
+	}
+	return nil
+}
+
+var reValidURL = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://\S+$`)
+
+// validateURL backs `validate:"url"`
+func validateURL(value interface{}, _ string) error {
+	if !reValidURL.MatchString(fmt.Sprintf("%v", value)) {
+		return fmt.Errorf("must be a valid URL")
+	}
+	return nil
+}
+
+var reValidEmail = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// validateEmail backs `validate:"email"`
+func validateEmail(value interface{}, _ string) error {
+	if !reValidEmail.MatchString(fmt.Sprintf("%v", value)) {
+		return fmt.Errorf("must be a valid email address")
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package envconf
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -133,6 +137,382 @@ func TestParse(t *testing.T) {
 	}
 }
 
+type TestDBConfig struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT" default:"5432"`
+}
+
+func TestParseNestedPrefix(t *testing.T) {
+
+	s := struct {
+		DB   TestDBConfig  `prefix:"DB_"`
+		HTTP *TestDBConfig `prefix:"HTTP_"`
+	}{}
+
+	os.Setenv("DB_HOST", "dbhost")
+	os.Setenv("HTTP_HOST", "httphost")
+	os.Setenv("HTTP_PORT", "8080")
+
+	if err := Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.DB.Host != "dbhost" {
+		t.Errorf("Expect 'dbhost' got '%s'", s.DB.Host)
+	}
+
+	if s.DB.Port != 5432 {
+		t.Errorf("Expect default 5432 got '%d'", s.DB.Port)
+	}
+
+	if s.HTTP == nil {
+		t.Fatal("Expected HTTP to be set")
+	}
+
+	if s.HTTP.Host != "httphost" || s.HTTP.Port != 8080 {
+		t.Errorf("Bad nested pointer parse: %v", s.HTTP)
+	}
+}
+
+func TestRegisterParser(t *testing.T) {
+
+	p := New()
+	p.RegisterParser((*url.URL)(nil), func(in string) (interface{}, error) {
+		return url.Parse(in)
+	})
+
+	s := struct {
+		URL *url.URL `env:"T_URL"`
+	}{}
+
+	os.Setenv("T_URL", "https://example.com/foo")
+	if err := p.Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.URL == nil || s.URL.Host != "example.com" || s.URL.Path != "/foo" {
+		t.Errorf("Bad URL parse: %v", s.URL)
+	}
+}
+
+func TestRegisterKindParser(t *testing.T) {
+
+	p := New()
+	p.RegisterKindParser(reflect.Int, func(in string) (interface{}, error) {
+		switch in {
+		case "on":
+			return 1, nil
+		case "off":
+			return 0, nil
+		default:
+			return nil, fmt.Errorf("unknown flag %q", in)
+		}
+	})
+
+	s := struct {
+		Flag int `env:"T_FLAG"`
+	}{}
+
+	os.Setenv("T_FLAG", "on")
+	if err := p.Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.Flag != 1 {
+		t.Errorf("Expected 1, got %v", s.Flag)
+	}
+}
+
+func TestTextUnmarshaler(t *testing.T) {
+
+	s := struct {
+		IP       net.IP        `env:"T_IP"`
+		Duration time.Duration `env:"T_DURATION"`
+	}{}
+
+	os.Setenv("T_IP", "127.0.0.1")
+	os.Setenv("T_DURATION", "90s")
+
+	if err := Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.IP.String() != "127.0.0.1" {
+		t.Errorf("Expect '127.0.0.1' got '%s'", s.IP.String())
+	}
+
+	if s.Duration != 90*time.Second {
+		t.Errorf("Expect 90s got %s", s.Duration)
+	}
+}
+
+func TestParseSlices(t *testing.T) {
+
+	s := struct {
+		Ints       []int           `env:"T_INTS"`
+		Durations  []time.Duration `env:"T_DURATIONS"`
+		Translated []string        `env:"T_TRANSLATED_SLICE"`
+		Separated  []string        `env:"T_SEPARATED" separator:";"`
+	}{}
+
+	os.Setenv("T_INTS", "1, 2, 3")
+	os.Setenv("T_DURATIONS", "1h,30m")
+	os.Setenv("T_TRANSLATED_SLICE", "!base64:Zm9v,!base64:YmFy")
+	os.Setenv("T_SEPARATED", "a;b;c")
+
+	if err := Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !reflect.DeepEqual(s.Ints, []int{1, 2, 3}) {
+		t.Errorf("Bad int slice: %v", s.Ints)
+	}
+
+	if !reflect.DeepEqual(s.Durations, []time.Duration{time.Hour, 30 * time.Minute}) {
+		t.Errorf("Bad duration slice: %v", s.Durations)
+	}
+
+	if !reflect.DeepEqual(s.Translated, []string{"foo", "bar"}) {
+		t.Errorf("Bad translated slice: %v", s.Translated)
+	}
+
+	if !reflect.DeepEqual(s.Separated, []string{"a", "b", "c"}) {
+		t.Errorf("Bad custom-separator slice: %v", s.Separated)
+	}
+}
+
+func TestParseArray(t *testing.T) {
+
+	s := struct {
+		Ints  [3]int `env:"T_ARR"`
+		Short [3]int `env:"T_ARR_SHORT"`
+	}{}
+
+	os.Setenv("T_ARR", "1,2,3")
+	os.Setenv("T_ARR_SHORT", "1,2")
+
+	if err := Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.Ints != [3]int{1, 2, 3} {
+		t.Errorf("Bad array parse: %v", s.Ints)
+	}
+
+	if s.Short != [3]int{1, 2, 0} {
+		t.Errorf("Bad short array parse: %v", s.Short)
+	}
+}
+
+func TestParseArrayTooLong(t *testing.T) {
+
+	s := struct {
+		Ints [2]int `env:"T_ARR_TOO_LONG"`
+	}{}
+
+	os.Setenv("T_ARR_TOO_LONG", "1,2,3")
+
+	if err := Parse(&s); err == nil {
+		t.Error("Expected an error for too many elements")
+	} else if !strings.Contains(err.Error(), "too many elements") {
+		t.Errorf("Unexpected error: %s", err.Error())
+	}
+}
+
+func TestParseNestedSlice(t *testing.T) {
+
+	s := struct {
+		Nested [][]int `env:"T_NESTED_SLICE"`
+	}{}
+
+	os.Setenv("T_NESTED_SLICE", "1|2,3|4")
+
+	if err := Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if !reflect.DeepEqual(s.Nested, [][]int{{1, 2}, {3, 4}}) {
+		t.Errorf("Bad nested slice parse: %v", s.Nested)
+	}
+}
+
+func TestWithSources(t *testing.T) {
+
+	p := New()
+	p.WithSources(
+		MapSource{"T_SOURCED": "from-override"},
+		MapSource{"T_SOURCED": "from-fallback", "T_FALLBACK_ONLY": "fallback-val"},
+	)
+
+	s := struct {
+		Sourced      string `env:"T_SOURCED"`
+		FallbackOnly string `env:"T_FALLBACK_ONLY"`
+	}{}
+
+	if err := p.Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.Sourced != "from-override" {
+		t.Errorf("Expected the first Source to win, got '%s'", s.Sourced)
+	}
+
+	if s.FallbackOnly != "fallback-val" {
+		t.Errorf("Expected to fall through to the second Source, got '%s'", s.FallbackOnly)
+	}
+}
+
+func TestDotEnvSource(t *testing.T) {
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	contents := "# a comment\n\nexport T_DOTENV_HOST=localhost\nT_DOTENV_NAME=\"quoted value\"\nT_DOTENV_SINGLE='single quoted'\n"
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	source, err := DotEnvSource(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	p := New()
+	p.WithSources(source)
+
+	s := struct {
+		Host   string `env:"T_DOTENV_HOST"`
+		Name   string `env:"T_DOTENV_NAME"`
+		Single string `env:"T_DOTENV_SINGLE"`
+	}{}
+
+	if err := p.Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.Host != "localhost" {
+		t.Errorf("Expected 'localhost' got '%s'", s.Host)
+	}
+
+	if s.Name != "quoted value" {
+		t.Errorf("Expected 'quoted value' got '%s'", s.Name)
+	}
+
+	if s.Single != "single quoted" {
+		t.Errorf("Expected 'single quoted' got '%s'", s.Single)
+	}
+}
+
+func TestValidate(t *testing.T) {
+
+	s := struct {
+		Port int    `env:"T_VALIDATE_PORT" validate:"min=1,max=65535"`
+		Env  string `env:"T_VALIDATE_ENV" validate:"oneof=dev staging prod"`
+	}{}
+
+	os.Setenv("T_VALIDATE_PORT", "8080")
+	os.Setenv("T_VALIDATE_ENV", "staging")
+
+	if err := Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.Port != 8080 || s.Env != "staging" {
+		t.Errorf("Unexpected values: %v", s)
+	}
+}
+
+func TestValidateRegexWithComma(t *testing.T) {
+
+	s := struct {
+		Code string `env:"T_VALIDATE_REGEX_COMMA" validate:"regex=^[a-z]{2,4}$"`
+	}{}
+
+	os.Setenv("T_VALIDATE_REGEX_COMMA", "ab")
+
+	if err := Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if s.Code != "ab" {
+		t.Errorf("Unexpected value: %v", s)
+	}
+}
+
+func TestRegisterValidator(t *testing.T) {
+
+	p := New()
+	p.RegisterValidator("even", func(value interface{}, _ string) error {
+		n, ok := value.(int)
+		if !ok || n%2 != 0 {
+			return fmt.Errorf("must be even")
+		}
+		return nil
+	})
+
+	s := struct {
+		N int `env:"T_VALIDATE_EVEN" validate:"even"`
+	}{}
+
+	os.Setenv("T_VALIDATE_EVEN", "3")
+	if err := p.Parse(&s); err == nil {
+		t.Fatal("Expected Error for odd value")
+	}
+
+	os.Setenv("T_VALIDATE_EVEN", "4")
+	if err := p.Parse(&s); err != nil {
+		t.Fatal(err.Error())
+	}
+	if s.N != 4 {
+		t.Errorf("Expected 4, got %v", s.N)
+	}
+}
+
+func TestRegisterValidatorOverridesBuiltin(t *testing.T) {
+
+	p := New()
+	p.RegisterValidator("oneof", func(value interface{}, arg string) error {
+		return fmt.Errorf("oneof overridden: %v/%s", value, arg)
+	})
+
+	s := struct {
+		Env string `env:"T_VALIDATE_OVERRIDDEN" validate:"oneof=dev staging prod"`
+	}{}
+
+	os.Setenv("T_VALIDATE_OVERRIDDEN", "dev")
+	err := p.Parse(&s)
+	if err == nil {
+		t.Fatal("Expected Error from overridden validator")
+	}
+	if !strings.Contains(err.Error(), "oneof overridden") {
+		t.Errorf("Expected the overridden validator to run, got: %s", err.Error())
+	}
+}
+
+func TestValidateAggregatesErrors(t *testing.T) {
+
+	s := struct {
+		Port int    `env:"T_VALIDATE_BAD_PORT" validate:"min=1,max=65535"`
+		Env  string `env:"T_VALIDATE_BAD_ENV" validate:"oneof=dev staging prod"`
+	}{}
+
+	os.Setenv("T_VALIDATE_BAD_PORT", "99999")
+	os.Setenv("T_VALIDATE_BAD_ENV", "nope")
+
+	err := Parse(&s)
+	if err == nil {
+		t.Fatal("Expected Error")
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected a *ValidationError, got %T", err)
+	}
+
+	if len(validationErr.Errors) != 2 {
+		t.Errorf("Expected 2 aggregated errors, got %d: %v", len(validationErr.Errors), validationErr.Errors)
+	}
+}
+
 func TestSadNotSet(t *testing.T) {
 	s := struct {
 		Simple string `env:"T_NOT_SET"`